@@ -0,0 +1,172 @@
+package main
+
+import "sort"
+
+// topCharactersLimit caps how many entries TopCharacters carries in the response.
+const topCharactersLimit = 10
+
+// AnalyticsRow is a generic {name, value} pair, used for simple scalar stats
+// that don't warrant their own typed field.
+type AnalyticsRow struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// CharacterCount pairs a character with its aggregate frequency across the corpus.
+type CharacterCount struct {
+	Character string `json:"character"`
+	Count     int    `json:"count"`
+}
+
+// LengthBucket is one bucket of the length histogram, covering [Min, Max).
+type LengthBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max"`
+	Count int `json:"count"`
+}
+
+// AnalyticsResponse is the body of GET /strings/analytics.
+type AnalyticsResponse struct {
+	Rows            []AnalyticsRow         `json:"rows"`
+	TotalCount      int                    `json:"total_count"`
+	PalindromeCount int                    `json:"palindrome_count"`
+	PalindromeRatio float64                `json:"palindrome_ratio"`
+	MeanLength      float64                `json:"mean_length"`
+	MedianLength    float64                `json:"median_length"`
+	MinLength       int                    `json:"min_length"`
+	MaxLength       int                    `json:"max_length"`
+	MeanWordCount   float64                `json:"mean_word_count"`
+	TopCharacters   []CharacterCount       `json:"top_characters"`
+	LengthHistogram []LengthBucket         `json:"length_histogram"`
+	FiltersApplied  map[string]interface{} `json:"filters_applied,omitempty"`
+}
+
+// computeAnalytics aggregates stats over the given (already filtered) rows.
+// A SQL-backed Store can later push individual stats down as queries; for
+// now every backend funnels through Store.Aggregate and this does the math
+// in Go.
+func computeAnalytics(results []AnalyzedString) AnalyticsResponse {
+	total := len(results)
+	if total == 0 {
+		return AnalyticsResponse{
+			Rows:            []AnalyticsRow{{Name: "total_count", Value: 0}},
+			TopCharacters:   []CharacterCount{},
+			LengthHistogram: []LengthBucket{},
+		}
+	}
+
+	palindromes := 0
+	lengthSum := 0
+	wordCountSum := 0
+	minLength := results[0].Properties.Length
+	maxLength := results[0].Properties.Length
+	charTotals := make(map[string]int)
+
+	for _, r := range results {
+		if r.Properties.IsPalindrome {
+			palindromes++
+		}
+		lengthSum += r.Properties.Length
+		wordCountSum += r.Properties.WordCount
+		if r.Properties.Length < minLength {
+			minLength = r.Properties.Length
+		}
+		if r.Properties.Length > maxLength {
+			maxLength = r.Properties.Length
+		}
+		for ch, count := range r.Properties.CharacterFrequencyMap {
+			charTotals[ch] += count
+		}
+	}
+
+	meanLength := float64(lengthSum) / float64(total)
+	meanWordCount := float64(wordCountSum) / float64(total)
+	medianLength := medianOfLengths(results)
+	topCharacters := topNCharacters(charTotals, topCharactersLimit)
+	histogram := buildLengthHistogram(results, maxLength)
+	palindromeRatio := float64(palindromes) / float64(total)
+
+	rows := []AnalyticsRow{
+		{Name: "total_count", Value: total},
+		{Name: "palindrome_count", Value: palindromes},
+		{Name: "palindrome_ratio", Value: palindromeRatio},
+		{Name: "mean_length", Value: meanLength},
+		{Name: "median_length", Value: medianLength},
+		{Name: "min_length", Value: minLength},
+		{Name: "max_length", Value: maxLength},
+		{Name: "mean_word_count", Value: meanWordCount},
+	}
+
+	return AnalyticsResponse{
+		Rows:            rows,
+		TotalCount:      total,
+		PalindromeCount: palindromes,
+		PalindromeRatio: palindromeRatio,
+		MeanLength:      meanLength,
+		MedianLength:    medianLength,
+		MinLength:       minLength,
+		MaxLength:       maxLength,
+		MeanWordCount:   meanWordCount,
+		TopCharacters:   topCharacters,
+		LengthHistogram: histogram,
+	}
+}
+
+// medianOfLengths returns the median string length, averaging the two middle
+// values for an even-sized corpus.
+func medianOfLengths(results []AnalyzedString) float64 {
+	lengths := make([]int, len(results))
+	for i, r := range results {
+		lengths[i] = r.Properties.Length
+	}
+	sort.Ints(lengths)
+
+	mid := len(lengths) / 2
+	if len(lengths)%2 == 1 {
+		return float64(lengths[mid])
+	}
+	return float64(lengths[mid-1]+lengths[mid]) / 2
+}
+
+// topNCharacters sorts aggregated character counts descending (ties broken
+// alphabetically for a deterministic response) and returns the top n.
+func topNCharacters(totals map[string]int, n int) []CharacterCount {
+	counts := make([]CharacterCount, 0, len(totals))
+	for ch, count := range totals {
+		counts = append(counts, CharacterCount{Character: ch, Count: count})
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Character < counts[j].Character
+	})
+
+	if len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// buildLengthHistogram buckets string lengths by powers of two: [0,1), [1,2),
+// [2,4), [4,8), ... up to and including maxLength.
+func buildLengthHistogram(results []AnalyzedString, maxLength int) []LengthBucket {
+	var buckets []LengthBucket
+	lower, upper := 0, 1
+	for lower <= maxLength {
+		buckets = append(buckets, LengthBucket{Min: lower, Max: upper})
+		lower, upper = upper, upper*2
+	}
+
+	for _, r := range results {
+		length := r.Properties.Length
+		for i := range buckets {
+			if length >= buckets[i].Min && length < buckets[i].Max {
+				buckets[i].Count++
+				break
+			}
+		}
+	}
+	return buckets
+}
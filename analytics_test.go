@@ -0,0 +1,66 @@
+package main
+
+import "testing"
+
+func rowsOfLengths(lengths ...int) []AnalyzedString {
+	rows := make([]AnalyzedString, len(lengths))
+	for i, l := range lengths {
+		rows[i] = AnalyzedString{Properties: StringProperties{Length: l}}
+	}
+	return rows
+}
+
+func TestMedianOfLengthsOdd(t *testing.T) {
+	if got, want := medianOfLengths(rowsOfLengths(1, 5, 3)), 3.0; got != want {
+		t.Errorf("medianOfLengths = %v, want %v", got, want)
+	}
+}
+
+func TestMedianOfLengthsEven(t *testing.T) {
+	if got, want := medianOfLengths(rowsOfLengths(1, 2, 3, 4)), 2.5; got != want {
+		t.Errorf("medianOfLengths = %v, want %v", got, want)
+	}
+}
+
+func TestBuildLengthHistogramBucketBoundaries(t *testing.T) {
+	// Buckets are [0,1), [1,2), [2,4), [4,8), [8,16) for maxLength=8. Each
+	// length below is the lower (inclusive) edge of a bucket, so it must
+	// land in that bucket and not the one below it.
+	rows := rowsOfLengths(0, 1, 2, 4, 8)
+	buckets := buildLengthHistogram(rows, 8)
+
+	want := []LengthBucket{
+		{Min: 0, Max: 1, Count: 1},
+		{Min: 1, Max: 2, Count: 1},
+		{Min: 2, Max: 4, Count: 1},
+		{Min: 4, Max: 8, Count: 1},
+		{Min: 8, Max: 16, Count: 1},
+	}
+	if len(buckets) != len(want) {
+		t.Fatalf("len(buckets) = %d, want %d: %+v", len(buckets), len(want), buckets)
+	}
+	for i, b := range buckets {
+		if b != want[i] {
+			t.Errorf("buckets[%d] = %+v, want %+v", i, b, want[i])
+		}
+	}
+}
+
+func TestTopNCharactersTiesBreakAlphabetically(t *testing.T) {
+	totals := map[string]int{"c": 2, "a": 2, "b": 2, "z": 1}
+
+	got := topNCharacters(totals, 3)
+	want := []CharacterCount{
+		{Character: "a", Count: 2},
+		{Character: "b", Count: 2},
+		{Character: "c", Count: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("len(got) = %d, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ndjsonContentType       = "application/x-ndjson"
+	bulkScannerMaxTokenSize = 10 * 1024 * 1024
+)
+
+// BulkItemRequest is a single entry in a POST /strings/bulk payload, whether
+// it arrives as part of a JSON array or as one line of NDJSON.
+type BulkItemRequest struct {
+	Value string `json:"value"`
+}
+
+// BulkItemResult reports the outcome of ingesting one BulkItemRequest.
+type BulkItemResult struct {
+	Index  int    `json:"index"`
+	Status string `json:"status"` // "created", "duplicate", or "error"
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// bulkCreateStrings handles POST /strings/bulk. A JSON array body is
+// analyzed in parallel across a worker pool and returned as one JSON array
+// of results; an application/x-ndjson body is streamed line-by-line and
+// the results are streamed back the same way, so callers can pipeline
+// arbitrarily large batches without buffering them in memory.
+func bulkCreateStrings(c *gin.Context) {
+	if c.ContentType() == ndjsonContentType {
+		streamBulkIngestNDJSON(c)
+		return
+	}
+
+	var items []BulkItemRequest
+	if err := c.ShouldBindJSON(&items); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body; expected a JSON array of {\"value\": ...} objects"})
+		return
+	}
+
+	c.JSON(http.StatusOK, bulkIngest(items))
+}
+
+// bulkIngest analyzes and stores every item in parallel across a worker
+// pool bounded by GOMAXPROCS.
+func bulkIngest(items []BulkItemRequest) []BulkItemResult {
+	results := make([]BulkItemResult, len(items))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(items) {
+		workers = len(items)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = ingestOne(i, items[i].Value)
+			}
+		}()
+	}
+
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// streamBulkIngestNDJSON reads the request body as newline-delimited JSON
+// and writes one result per input line as soon as it's ingested, flushing
+// after each line.
+func streamBulkIngestNDJSON(c *gin.Context) {
+	c.Header("Content-Type", ndjsonContentType)
+	c.Status(http.StatusOK)
+
+	scanner := bufio.NewScanner(c.Request.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), bulkScannerMaxTokenSize)
+
+	encoder := json.NewEncoder(c.Writer)
+	flusher, canFlush := c.Writer.(http.Flusher)
+
+	index := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var item BulkItemRequest
+		var result BulkItemResult
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			result = BulkItemResult{Index: index, Status: "error", Error: "invalid JSON: " + err.Error()}
+		} else {
+			result = ingestOne(index, item.Value)
+		}
+
+		encoder.Encode(result)
+		if canFlush {
+			flusher.Flush()
+		}
+		index++
+	}
+
+	if err := scanner.Err(); err != nil {
+		encoder.Encode(BulkItemResult{Index: index, Status: "error", Error: "reading request body: " + err.Error()})
+	}
+}
+
+// ingestOne analyzes and stores a single value, reporting duplicates and
+// store errors as a result rather than aborting the batch.
+func ingestOne(index int, value string) BulkItemResult {
+	props := analyzeString(value)
+	analyzed := &AnalyzedString{
+		ID:         props.SHA256Hash,
+		Value:      value,
+		Properties: props,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err := store.Put(analyzed); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return BulkItemResult{Index: index, Status: "duplicate", ID: analyzed.ID, Error: "String already exists in the system"}
+		}
+		return BulkItemResult{Index: index, Status: "error", Error: err.Error()}
+	}
+
+	return BulkItemResult{Index: index, Status: "created", ID: analyzed.ID}
+}
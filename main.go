@@ -2,8 +2,12 @@ package main
 
 import (
 	"crypto/sha256"
+	"errors"
+	"flag"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
@@ -13,6 +17,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const defaultSQLitePath = "strings.db"
+
 // StringProperties holds the computed properties of an analyzed string
 type StringProperties struct {
 	Length               int            `json:"length"`
@@ -43,31 +49,72 @@ type FilterParams struct {
 	MaxLength        *int    `form:"max_length"`
 	WordCount        *int    `form:"word_count"`
 	ContainsCharacter *string `form:"contains_character"`
+	Offset           *int    `form:"offset"`
+	Limit            *int    `form:"limit"`
+	SortBy           *string `form:"sort_by"`
+	SortOrder        *string `form:"sort_order"`
+}
+
+const (
+	defaultOffset    = 0
+	defaultLimit     = 50
+	maxLimit         = 500
+	defaultSortBy    = "created_at"
+	defaultSortOrder = "asc"
+)
+
+// validSortFields enumerates the fields GET /strings may sort on
+var validSortFields = map[string]bool{
+	"created_at":        true,
+	"length":            true,
+	"word_count":        true,
+	"unique_characters": true,
 }
 
-// NaturalLanguageQuery holds parsed natural language query results
+// NaturalLanguageQuery holds the original text and its compiled DSL expression
 type NaturalLanguageQuery struct {
-	Original      string                 `json:"original"`
-	ParsedFilters map[string]interface{} `json:"parsed_filters"`
+	Original           string `json:"original"`
+	CompiledExpression string `json:"compiled_expression"`
+}
+
+// QueryRequest is the request body for POST /strings/query
+type QueryRequest struct {
+	Query string `json:"query" binding:"required"`
 }
 
 // FilterResponse wraps filtered results with metadata
 type FilterResponse struct {
 	Data          []AnalyzedString         `json:"data"`
 	Count         int                      `json:"count"`
+	Total         int                      `json:"total"`
+	Offset        int                      `json:"offset"`
+	Limit         int                      `json:"limit"`
+	NextOffset    *int                     `json:"next_offset"`
 	FiltersApplied map[string]interface{}  `json:"filters_applied,omitempty"`
 	InterpretedQuery *NaturalLanguageQuery `json:"interpreted_query,omitempty"`
 }
 
-// StringStore is a simple in-memory storage for analyzed strings
-var stringStore = make(map[string]*AnalyzedString)
+// store is the Store backing all handlers, selected at startup in main.
+var store Store
 
 func main() {
+	backendFlag := flag.String("store", "", "storage backend to use: memory or sqlite (default memory, or $STORE_BACKEND)")
+	flag.Parse()
+
+	s, err := newStore(*backendFlag)
+	if err != nil {
+		log.Fatalf("initializing store: %v", err)
+	}
+	store = s
+
 	router := gin.Default()
 
 	// Routes
 	router.POST("/strings", createString)
+	router.POST("/strings/bulk", bulkCreateStrings)
 	router.GET("/strings", getAllStrings)
+	router.GET("/strings/analytics", getAnalytics)
+	router.POST("/strings/query", queryStrings)
 	router.GET("/strings/filter-by-natural-language", filterByNaturalLanguage)
 	router.GET("/strings/:value", getString)
 	router.DELETE("/strings/:value", deleteString)
@@ -75,6 +122,27 @@ func main() {
 	router.Run(":8080")
 }
 
+// newStore picks a Store implementation based on the --store flag, falling
+// back to the STORE_BACKEND env var and finally the in-memory backend.
+func newStore(flagValue string) (Store, error) {
+	backend := flagValue
+	if backend == "" {
+		backend = os.Getenv("STORE_BACKEND")
+	}
+	if backend == "" {
+		backend = "memory"
+	}
+
+	switch backend {
+	case "memory":
+		return NewMemoryStore(), nil
+	case "sqlite":
+		return NewSQLiteStore(defaultSQLitePath)
+	default:
+		return nil, fmt.Errorf("unknown store backend %q (expected memory or sqlite)", backend)
+	}
+}
+
 // createString handles POST /strings
 func createString(c *gin.Context) {
 	var req CreateStringRequest
@@ -94,12 +162,6 @@ func createString(c *gin.Context) {
 	props := analyzeString(req.Value)
 	hash := props.SHA256Hash
 
-	// Check if string already exists
-	if _, exists := stringStore[hash]; exists {
-		c.JSON(http.StatusConflict, gin.H{"error": "String already exists in the system"})
-		return
-	}
-
 	// Create and store the analyzed string
 	analyzed := &AnalyzedString{
 		ID:        hash,
@@ -108,7 +170,14 @@ func createString(c *gin.Context) {
 		CreatedAt: time.Now().UTC(),
 	}
 
-	stringStore[hash] = analyzed
+	if err := store.Put(analyzed); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "String already exists in the system"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store analyzed string"})
+		return
+	}
 
 	c.JSON(http.StatusCreated, analyzed)
 }
@@ -121,7 +190,11 @@ func getString(c *gin.Context) {
 	hash := calculateSHA256(value)
 
 	// Find the string in store
-	analyzed, exists := stringStore[hash]
+	analyzed, exists, err := store.Get(hash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch string"})
+		return
+	}
 	if !exists {
 		c.JSON(http.StatusNotFound, gin.H{"error": "String does not exist in the system"})
 		return
@@ -130,7 +203,7 @@ func getString(c *gin.Context) {
 	c.JSON(http.StatusOK, analyzed)
 }
 
-// getAllStrings handles GET /strings with optional filtering
+// getAllStrings handles GET /strings with optional filtering, sorting and pagination
 func getAllStrings(c *gin.Context) {
 	var params FilterParams
 
@@ -139,20 +212,40 @@ func getAllStrings(c *gin.Context) {
 		return
 	}
 
-	// Collect all strings and apply filters
-	var results []AnalyzedString
-	for _, analyzed := range stringStore {
-		if matchesFilters(analyzed, params) {
-			results = append(results, *analyzed)
-		}
+	offset, limit, sortBy, sortOrder, err := resolvePageParams(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	// Sort by creation time for consistent ordering
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].CreatedAt.Before(results[j].CreatedAt)
-	})
+	page, total, err := store.List(params, PageParams{Offset: offset, Limit: limit, SortBy: sortBy, SortOrder: sortOrder})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list strings"})
+		return
+	}
+
+	var nextOffset *int
+	if offset+limit < total {
+		n := offset + limit
+		nextOffset = &n
+	}
 
-	// Build filters applied map
+	response := FilterResponse{
+		Data:          page,
+		Count:         len(page),
+		Total:         total,
+		Offset:        offset,
+		Limit:         limit,
+		NextOffset:    nextOffset,
+		FiltersApplied: buildFiltersApplied(params),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// buildFiltersApplied reports which FilterParams were actually set, for
+// inclusion in filter/analytics responses.
+func buildFiltersApplied(params FilterParams) map[string]interface{} {
 	filtersApplied := make(map[string]interface{})
 	if params.IsPalindrome != nil {
 		filtersApplied["is_palindrome"] = *params.IsPalindrome
@@ -169,17 +262,114 @@ func getAllStrings(c *gin.Context) {
 	if params.ContainsCharacter != nil {
 		filtersApplied["contains_character"] = *params.ContainsCharacter
 	}
+	return filtersApplied
+}
 
-	response := FilterResponse{
-		Data:          results,
-		Count:         len(results),
-		FiltersApplied: filtersApplied,
+// getAnalytics handles GET /strings/analytics, returning aggregate
+// statistics across all strings matching the given FilterParams.
+func getAnalytics(c *gin.Context) {
+	var params FilterParams
+
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameter values or types"})
+		return
+	}
+
+	results, err := store.Aggregate(params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute analytics"})
+		return
 	}
 
+	response := computeAnalytics(results)
+	response.FiltersApplied = buildFiltersApplied(params)
+
 	c.JSON(http.StatusOK, response)
 }
 
-// filterByNaturalLanguage handles GET /strings/filter-by-natural-language
+// resolvePageParams applies defaults to offset/limit/sort_by/sort_order and validates them
+func resolvePageParams(params FilterParams) (offset, limit int, sortBy, sortOrder string, err error) {
+	offset = defaultOffset
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if offset < 0 {
+		return 0, 0, "", "", fmt.Errorf("'offset' must be >= 0")
+	}
+
+	limit = defaultLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxLimit {
+		return 0, 0, "", "", fmt.Errorf("'limit' must be between 1 and %d", maxLimit)
+	}
+
+	sortBy = defaultSortBy
+	if params.SortBy != nil {
+		sortBy = *params.SortBy
+	}
+	if !validSortFields[sortBy] {
+		return 0, 0, "", "", fmt.Errorf("'sort_by' must be one of created_at, length, word_count, unique_characters")
+	}
+
+	sortOrder = defaultSortOrder
+	if params.SortOrder != nil {
+		sortOrder = *params.SortOrder
+	}
+	if sortOrder != "asc" && sortOrder != "desc" {
+		return 0, 0, "", "", fmt.Errorf("'sort_order' must be 'asc' or 'desc'")
+	}
+
+	return offset, limit, sortBy, sortOrder, nil
+}
+
+// sortResults sorts results in place by the given field and order. Ties on
+// the sort field break on ID so that two calls over the same data always
+// return rows in the same relative order — required for offset/limit paging
+// to be stable, since results are collected from map iteration order.
+func sortResults(results []AnalyzedString, sortBy, sortOrder string) {
+	fieldLess := func(i, j int) bool {
+		switch sortBy {
+		case "length":
+			return results[i].Properties.Length < results[j].Properties.Length
+		case "word_count":
+			return results[i].Properties.WordCount < results[j].Properties.WordCount
+		case "unique_characters":
+			return results[i].Properties.UniqueCharacters < results[j].Properties.UniqueCharacters
+		default:
+			return results[i].CreatedAt.Before(results[j].CreatedAt)
+		}
+	}
+	fieldEqual := func(i, j int) bool {
+		return !fieldLess(i, j) && !fieldLess(j, i)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if fieldEqual(i, j) {
+			return results[i].ID < results[j].ID
+		}
+		if sortOrder == "desc" {
+			return fieldLess(j, i)
+		}
+		return fieldLess(i, j)
+	})
+}
+
+// paginate slices results to the requested offset/limit window
+func paginate(results []AnalyzedString, offset, limit int) []AnalyzedString {
+	if offset >= len(results) {
+		return []AnalyzedString{}
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end]
+}
+
+// filterByNaturalLanguage handles GET /strings/filter-by-natural-language by
+// compiling the free text into the same Expr DSL POST /strings/query evaluates.
 func filterByNaturalLanguage(c *gin.Context) {
 	query := c.Query("query")
 
@@ -188,43 +378,91 @@ func filterByNaturalLanguage(c *gin.Context) {
 		return
 	}
 
-	// Parse natural language query
-	parsedFilters, err := parseNaturalLanguageQuery(query)
+	expr, err := translateNaturalLanguageToExpr(query)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unable to parse natural language query: %v", err)})
 		return
 	}
 
-	// Convert parsed filters to FilterParams
-	filterParams := convertParsedFiltersToParams(parsedFilters)
+	results, err := evaluateExpr(expr)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to filter strings"})
+		return
+	}
 
-	// Collect all strings and apply filters
-	var results []AnalyzedString
-	for _, analyzed := range stringStore {
-		if matchesFilters(analyzed, filterParams) {
-			results = append(results, *analyzed)
-		}
+	interpretedQuery := &NaturalLanguageQuery{
+		Original:           query,
+		CompiledExpression: expr.String(),
 	}
 
-	// Sort by creation time
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].CreatedAt.Before(results[j].CreatedAt)
-	})
+	response := FilterResponse{
+		Data:             results,
+		Count:            len(results),
+		Total:            len(results),
+		InterpretedQuery: interpretedQuery,
+	}
 
-	interpretedQuery := &NaturalLanguageQuery{
-		Original:      query,
-		ParsedFilters: parsedFilters,
+	c.JSON(http.StatusOK, response)
+}
+
+// queryStrings handles POST /strings/query, evaluating a boolean filter
+// expression (see query.go) against every stored string.
+func queryStrings(c *gin.Context) {
+	var req QueryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body or missing 'query' field"})
+		return
+	}
+
+	expr, err := ParseExpr(req.Query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unable to parse query expression: %v", err)})
+		return
+	}
+
+	results, err := evaluateExpr(expr)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query strings"})
+		return
 	}
 
 	response := FilterResponse{
-		Data:                results,
-		Count:               len(results),
-		InterpretedQuery:    interpretedQuery,
+		Data:  results,
+		Count: len(results),
+		Total: len(results),
+		InterpretedQuery: &NaturalLanguageQuery{
+			Original:           req.Query,
+			CompiledExpression: expr.String(),
+		},
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// evaluateExpr runs expr against every stored string, sorted by creation
+// time like the rest of the filter endpoints.
+func evaluateExpr(expr Expr) ([]AnalyzedString, error) {
+	all, err := store.Aggregate(FilterParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AnalyzedString
+	for _, analyzed := range all {
+		row := analyzed
+		if expr.Evaluate(&row) {
+			results = append(results, analyzed)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CreatedAt.Before(results[j].CreatedAt)
+	})
+
+	return results, nil
+}
+
 // deleteString handles DELETE /strings/:value
 func deleteString(c *gin.Context) {
 	value := c.Param("value")
@@ -232,15 +470,17 @@ func deleteString(c *gin.Context) {
 	// Calculate hash of the provided value to lookup
 	hash := calculateSHA256(value)
 
-	// Check if string exists
-	if _, exists := stringStore[hash]; !exists {
+	// Delete the string
+	deleted, err := store.Delete(hash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete string"})
+		return
+	}
+	if !deleted {
 		c.JSON(http.StatusNotFound, gin.H{"error": "String does not exist in the system"})
 		return
 	}
 
-	// Delete the string
-	delete(stringStore, hash)
-
 	c.JSON(http.StatusNoContent, nil)
 }
 
@@ -338,85 +578,3 @@ func matchesFilters(analyzed *AnalyzedString, params FilterParams) bool {
 
 	return true
 }
-
-// parseNaturalLanguageQuery parses natural language filter queries
-func parseNaturalLanguageQuery(query string) (map[string]interface{}, error) {
-	lowerQuery := strings.ToLower(query)
-	filters := make(map[string]interface{})
-
-	// Check for word count patterns
-	if strings.Contains(lowerQuery, "single word") {
-		filters["word_count"] = 1
-	} else if strings.Contains(lowerQuery, "two word") || strings.Contains(lowerQuery, "2 word") {
-		filters["word_count"] = 2
-	} else if strings.Contains(lowerQuery, "three word") || strings.Contains(lowerQuery, "3 word") {
-		filters["word_count"] = 3
-	}
-
-	// Check for palindrome pattern
-	if strings.Contains(lowerQuery, "palindrom") {
-		filters["is_palindrome"] = true
-	}
-
-	// Check for length patterns
-	lengthPattern := regexp.MustCompile(`longer than (\d+)`)
-	if matches := lengthPattern.FindStringSubmatch(lowerQuery); matches != nil {
-		minLength := 0
-		fmt.Sscanf(matches[1], "%d", &minLength)
-		filters["min_length"] = minLength + 1
-	}
-
-	shorterPattern := regexp.MustCompile(`shorter than (\d+)`)
-	if matches := shorterPattern.FindStringSubmatch(lowerQuery); matches != nil {
-		maxLength := 0
-		fmt.Sscanf(matches[1], "%d", &maxLength)
-		filters["max_length"] = maxLength - 1
-	}
-
-	// Check for character patterns
-	charPattern := regexp.MustCompile(`(?:contain|with) (?:the |letter |character )?'?([a-z])'?`)
-	if matches := charPattern.FindStringSubmatch(lowerQuery); matches != nil {
-		filters["contains_character"] = matches[1]
-	}
-
-	// Check for vowel patterns
-	if strings.Contains(lowerQuery, "first vowel") {
-		filters["contains_character"] = "a"
-	} else if strings.Contains(lowerQuery, "last vowel") {
-		filters["contains_character"] = "u"
-	}
-
-	// If no filters could be parsed, return error
-	if len(filters) == 0 {
-		return nil, fmt.Errorf("unable to parse any filters from query")
-	}
-
-	return filters, nil
-}
-
-// convertParsedFiltersToParams converts parsed filters map to FilterParams struct
-func convertParsedFiltersToParams(parsed map[string]interface{}) FilterParams {
-	params := FilterParams{}
-
-	if isPalin, ok := parsed["is_palindrome"].(bool); ok {
-		params.IsPalindrome = &isPalin
-	}
-
-	if minLen, ok := parsed["min_length"].(int); ok {
-		params.MinLength = &minLen
-	}
-
-	if maxLen, ok := parsed["max_length"].(int); ok {
-		params.MaxLength = &maxLen
-	}
-
-	if wordCnt, ok := parsed["word_count"].(int); ok {
-		params.WordCount = &wordCnt
-	}
-
-	if char, ok := parsed["contains_character"].(string); ok {
-		params.ContainsCharacter = &char
-	}
-
-	return params
-}
\ No newline at end of file
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func rowWithID(id string, length int) AnalyzedString {
+	return AnalyzedString{
+		ID:         id,
+		Properties: StringProperties{Length: length},
+		CreatedAt:  time.Unix(0, 0).UTC(),
+	}
+}
+
+func TestSortResultsIsDeterministicOnTies(t *testing.T) {
+	base := []AnalyzedString{
+		rowWithID("c", 5),
+		rowWithID("a", 5),
+		rowWithID("b", 5),
+	}
+
+	var first []string
+	for i := 0; i < 20; i++ {
+		results := append([]AnalyzedString(nil), base...)
+		sortResults(results, "length", "asc")
+
+		ids := make([]string, len(results))
+		for j, r := range results {
+			ids[j] = r.ID
+		}
+
+		if first == nil {
+			first = ids
+			continue
+		}
+		for j := range ids {
+			if ids[j] != first[j] {
+				t.Fatalf("sortResults order changed across calls: got %v, want %v", ids, first)
+			}
+		}
+	}
+
+	if got, want := first, []string{"a", "b", "c"}; got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("sortResults with tied length should break ties on ID ascending, got %v", got)
+	}
+}
+
+func TestSortResultsDescStillBreaksTiesAscendingByID(t *testing.T) {
+	results := []AnalyzedString{
+		rowWithID("z", 5),
+		rowWithID("x", 10),
+		rowWithID("y", 5),
+	}
+	sortResults(results, "length", "desc")
+
+	want := []string{"x", "y", "z"}
+	for i, r := range results {
+		if r.ID != want[i] {
+			t.Fatalf("sortResults desc order = %v, want %v", idsOf(results), want)
+		}
+	}
+}
+
+func idsOf(results []AnalyzedString) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestPaginate(t *testing.T) {
+	results := []AnalyzedString{rowWithID("a", 1), rowWithID("b", 2), rowWithID("c", 3)}
+
+	tests := []struct {
+		name   string
+		offset int
+		limit  int
+		want   []string
+	}{
+		{"first page", 0, 2, []string{"a", "b"}},
+		{"second page", 2, 2, []string{"c"}},
+		{"offset past end", 5, 2, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := paginate(results, tt.offset, tt.limit)
+			if len(page) != len(tt.want) {
+				t.Fatalf("paginate(%d, %d) = %v, want %v", tt.offset, tt.limit, idsOf(page), tt.want)
+			}
+			for i, r := range page {
+				if r.ID != tt.want[i] {
+					t.Fatalf("paginate(%d, %d) = %v, want %v", tt.offset, tt.limit, idsOf(page), tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is a node in a compiled filter expression. Every node can evaluate
+// itself against a row and render itself back to the DSL text, so the
+// compiled expression can be echoed back to callers.
+type Expr interface {
+	Evaluate(row *AnalyzedString) bool
+	String() string
+}
+
+// BinOp is a binary "and"/"or" combination of two expressions.
+type BinOp struct {
+	Op    string // "and" or "or"
+	Left  Expr
+	Right Expr
+}
+
+func (b *BinOp) Evaluate(row *AnalyzedString) bool {
+	if b.Op == "and" {
+		return b.Left.Evaluate(row) && b.Right.Evaluate(row)
+	}
+	return b.Left.Evaluate(row) || b.Right.Evaluate(row)
+}
+
+func (b *BinOp) String() string {
+	return fmt.Sprintf("(%s %s %s)", b.Left, b.Op, b.Right)
+}
+
+// UnaryOp is a "not" negation of an expression.
+type UnaryOp struct {
+	Op      string // "not"
+	Operand Expr
+}
+
+func (u *UnaryOp) Evaluate(row *AnalyzedString) bool {
+	return !u.Operand.Evaluate(row)
+}
+
+func (u *UnaryOp) String() string {
+	return fmt.Sprintf("not %s", u.Operand)
+}
+
+// Comparison compares a numeric field against a literal, e.g. `length > 5`.
+type Comparison struct {
+	Field string
+	Op    string
+	Value float64
+}
+
+func (c *Comparison) Evaluate(row *AnalyzedString) bool {
+	field := numericField(row, c.Field)
+	switch c.Op {
+	case ">":
+		return field > c.Value
+	case ">=":
+		return field >= c.Value
+	case "<":
+		return field < c.Value
+	case "<=":
+		return field <= c.Value
+	case "==":
+		return field == c.Value
+	case "!=":
+		return field != c.Value
+	default:
+		return false
+	}
+}
+
+func (c *Comparison) String() string {
+	return fmt.Sprintf("%s %s %v", c.Field, c.Op, c.Value)
+}
+
+// BoolField is a bare boolean field reference, e.g. `is_palindrome`.
+type BoolField struct {
+	Field string
+}
+
+func (b *BoolField) Evaluate(row *AnalyzedString) bool {
+	return boolField(row, b.Field)
+}
+
+func (b *BoolField) String() string {
+	return b.Field
+}
+
+// FuncCall is a single-argument predicate function, e.g. `contains('e')`.
+type FuncCall struct {
+	Name string
+	Arg  string
+}
+
+func (f *FuncCall) Evaluate(row *AnalyzedString) bool {
+	switch f.Name {
+	case "contains":
+		_, exists := row.Properties.CharacterFrequencyMap[strings.ToLower(f.Arg)]
+		return exists
+	default:
+		return false
+	}
+}
+
+func (f *FuncCall) String() string {
+	return fmt.Sprintf("%s('%s')", f.Name, f.Arg)
+}
+
+// numericFields, boolFields and funcNames are the grammar's known field and
+// function names. parseAtom validates against these at parse time so a
+// typo'd field (e.g. "lenght") is a parse error instead of silently
+// evaluating to zero/false for every row.
+var numericFields = map[string]bool{
+	"length":            true,
+	"word_count":        true,
+	"unique_characters": true,
+}
+
+var boolFields = map[string]bool{
+	"is_palindrome": true,
+}
+
+var funcNames = map[string]bool{
+	"contains": true,
+}
+
+func numericField(row *AnalyzedString, field string) float64 {
+	switch field {
+	case "length":
+		return float64(row.Properties.Length)
+	case "word_count":
+		return float64(row.Properties.WordCount)
+	case "unique_characters":
+		return float64(row.Properties.UniqueCharacters)
+	default:
+		return 0
+	}
+}
+
+func boolField(row *AnalyzedString, field string) bool {
+	switch field {
+	case "is_palindrome":
+		return row.Properties.IsPalindrome
+	default:
+		return false
+	}
+}
+
+// token is a single lexical token of the filter expression grammar.
+type token struct {
+	kind string // LPAREN, RPAREN, COMMA, OP, IDENT, NUMBER, STRING, EOF
+	text string
+}
+
+// tokenizeExpr scans a filter expression into tokens.
+func tokenizeExpr(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	i := 0
+
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n':
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: "LPAREN"})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: "RPAREN"})
+			i++
+		case r == '\'' || r == '"':
+			quote := r
+			j := i + 1
+			for j < len(runes) && runes[j] != quote {
+				j++
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string literal")
+			}
+			tokens = append(tokens, token{kind: "STRING", text: string(runes[i+1 : j])})
+			i = j + 1
+		case r == '>' || r == '<' || r == '=' || r == '!':
+			op := string(r)
+			j := i + 1
+			if j < len(runes) && runes[j] == '=' {
+				op += "="
+				j++
+			}
+			if op == "=" || op == "!" {
+				return nil, fmt.Errorf("unexpected %q: did you mean %q?", op, op+"=")
+			}
+			tokens = append(tokens, token{kind: "OP", text: op})
+			i = j
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: "NUMBER", text: string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, token{kind: "IDENT", text: string(runes[i:j])})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+
+	tokens = append(tokens, token{kind: "EOF"})
+	return tokens, nil
+}
+
+// exprParser is a small precedence-climbing (Pratt-style) parser for the
+// filter expression grammar: comparisons and boolean fields combined with
+// and/or/not and parentheses, `or` binding loosest and `not` tightest.
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+// ParseExpr parses a filter expression string into an Expr AST.
+func ParseExpr(input string) (Expr, error) {
+	tokens, err := tokenizeExpr(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != "EOF" {
+		return nil, fmt.Errorf("unexpected trailing input near %q", p.peek().text)
+	}
+	return expr, nil
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	p.pos++
+	return t
+}
+
+func (p *exprParser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == "IDENT" && strings.ToLower(tok.text) == keyword
+}
+
+func (p *exprParser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinOp{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinOp{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (Expr, error) {
+	if p.peekKeyword("not") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryOp{Op: "not", Operand: operand}, nil
+	}
+	return p.parseAtom()
+}
+
+func (p *exprParser) parseAtom() (Expr, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case "LPAREN":
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != "RPAREN" {
+			return nil, fmt.Errorf("expected ')'")
+		}
+		p.next()
+		return expr, nil
+
+	case "IDENT":
+		p.next()
+		name := strings.ToLower(tok.text)
+
+		if p.peek().kind == "LPAREN" {
+			if !funcNames[name] {
+				return nil, fmt.Errorf("unknown function %q", name)
+			}
+			p.next()
+			argTok := p.next()
+			if argTok.kind != "STRING" {
+				return nil, fmt.Errorf("expected string argument to %s(...)", name)
+			}
+			if p.peek().kind != "RPAREN" {
+				return nil, fmt.Errorf("expected ')'")
+			}
+			p.next()
+			return &FuncCall{Name: name, Arg: argTok.text}, nil
+		}
+
+		if p.peek().kind == "OP" {
+			if !numericFields[name] {
+				return nil, fmt.Errorf("unknown field %q", name)
+			}
+			opTok := p.next()
+			valTok := p.next()
+			if valTok.kind != "NUMBER" {
+				return nil, fmt.Errorf("expected number after %q", opTok.text)
+			}
+			value, err := strconv.ParseFloat(valTok.text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", valTok.text)
+			}
+			return &Comparison{Field: name, Op: opTok.text, Value: value}, nil
+		}
+
+		if !boolFields[name] {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		return &BoolField{Field: name}, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected token %q", tok.text)
+	}
+}
+
+var (
+	longerThanPattern   = regexp.MustCompile(`longer than (\d+)`)
+	shorterThanPattern  = regexp.MustCompile(`shorter than (\d+)`)
+	containsCharPattern = regexp.MustCompile(`(?:contain|with) (?:the |letter |character )?'?([a-z])'?`)
+)
+
+// translateNaturalLanguageToExpr compiles a free-text query into an Expr by
+// recognizing the same phrases the old hand-rolled parser did, joined with
+// `and`, then handing the result to the same evaluator POST /strings/query uses.
+func translateNaturalLanguageToExpr(query string) (Expr, error) {
+	lowerQuery := strings.ToLower(query)
+	var parts []Expr
+
+	switch {
+	case strings.Contains(lowerQuery, "single word"):
+		parts = append(parts, &Comparison{Field: "word_count", Op: "==", Value: 1})
+	case strings.Contains(lowerQuery, "two word") || strings.Contains(lowerQuery, "2 word"):
+		parts = append(parts, &Comparison{Field: "word_count", Op: "==", Value: 2})
+	case strings.Contains(lowerQuery, "three word") || strings.Contains(lowerQuery, "3 word"):
+		parts = append(parts, &Comparison{Field: "word_count", Op: "==", Value: 3})
+	}
+
+	if strings.Contains(lowerQuery, "palindrom") {
+		parts = append(parts, &BoolField{Field: "is_palindrome"})
+	}
+
+	if matches := longerThanPattern.FindStringSubmatch(lowerQuery); matches != nil {
+		n, _ := strconv.Atoi(matches[1])
+		parts = append(parts, &Comparison{Field: "length", Op: ">", Value: float64(n)})
+	}
+
+	if matches := shorterThanPattern.FindStringSubmatch(lowerQuery); matches != nil {
+		n, _ := strconv.Atoi(matches[1])
+		parts = append(parts, &Comparison{Field: "length", Op: "<", Value: float64(n)})
+	}
+
+	switch {
+	case containsCharPattern.MatchString(lowerQuery):
+		matches := containsCharPattern.FindStringSubmatch(lowerQuery)
+		parts = append(parts, &FuncCall{Name: "contains", Arg: matches[1]})
+	case strings.Contains(lowerQuery, "first vowel"):
+		parts = append(parts, &FuncCall{Name: "contains", Arg: "a"})
+	case strings.Contains(lowerQuery, "last vowel"):
+		parts = append(parts, &FuncCall{Name: "contains", Arg: "u"})
+	}
+
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("unable to parse any filters from query")
+	}
+
+	expr := parts[0]
+	for _, part := range parts[1:] {
+		expr = &BinOp{Op: "and", Left: expr, Right: part}
+	}
+	return expr, nil
+}
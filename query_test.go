@@ -0,0 +1,140 @@
+package main
+
+import "testing"
+
+func TestParseExprEvaluate(t *testing.T) {
+	row := &AnalyzedString{
+		Properties: StringProperties{
+			Length:                6,
+			WordCount:             2,
+			UniqueCharacters:      4,
+			IsPalindrome:          true,
+			CharacterFrequencyMap: map[string]int{"e": 1, "l": 2},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{"simple comparison true", "length > 5", true},
+		{"simple comparison false", "length > 10", false},
+		{"equality", "word_count == 2", true},
+		{"bare bool field", "is_palindrome", true},
+		{"not", "not is_palindrome", false},
+		{"func call hit", "contains('e')", true},
+		{"func call miss", "contains('z')", false},
+		{"and both true", "length > 5 and is_palindrome", true},
+		{"and one false", "length > 5 and contains('z')", false},
+		{"or one true", "length > 100 or contains('e')", true},
+		{"parentheses", "(length > 100 or contains('e')) and is_palindrome", true},
+		{"precedence: and before or", "length > 100 and is_palindrome or contains('e')", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseExpr(tt.query)
+			if err != nil {
+				t.Fatalf("ParseExpr(%q): unexpected error: %v", tt.query, err)
+			}
+			if got := expr.Evaluate(row); got != tt.want {
+				t.Errorf("ParseExpr(%q).Evaluate(row) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExprRejectsUnknownFields(t *testing.T) {
+	tests := []string{
+		"lenght > 5",
+		"wordcount == 3",
+		"is_palindromee",
+		"made_up_field >= 1",
+	}
+
+	for _, query := range tests {
+		t.Run(query, func(t *testing.T) {
+			if _, err := ParseExpr(query); err == nil {
+				t.Errorf("ParseExpr(%q) = nil error, want an unknown-field error", query)
+			}
+		})
+	}
+}
+
+func TestParseExprRejectsUnknownFunctions(t *testing.T) {
+	if _, err := ParseExpr("startswith('e')"); err == nil {
+		t.Error("ParseExpr(\"startswith('e')\") = nil error, want an unknown-function error")
+	}
+}
+
+func TestParseExprSyntaxErrors(t *testing.T) {
+	tests := []string{
+		"length >",
+		"(length > 5",
+		"length > 5)",
+		"contains(5)",
+		"and length > 5",
+	}
+
+	for _, query := range tests {
+		t.Run(query, func(t *testing.T) {
+			if _, err := ParseExpr(query); err == nil {
+				t.Errorf("ParseExpr(%q) = nil error, want a syntax error", query)
+			}
+		})
+	}
+}
+
+func TestExprString(t *testing.T) {
+	expr, err := ParseExpr("length > 5 and is_palindrome")
+	if err != nil {
+		t.Fatalf("ParseExpr: unexpected error: %v", err)
+	}
+
+	want := "(length > 5 and is_palindrome)"
+	if got := expr.String(); got != want {
+		t.Errorf("expr.String() = %q, want %q", got, want)
+	}
+}
+
+func TestTranslateNaturalLanguageToExpr(t *testing.T) {
+	row := &AnalyzedString{
+		Properties: StringProperties{
+			Length:                8,
+			WordCount:             1,
+			IsPalindrome:          true,
+			CharacterFrequencyMap: map[string]int{"e": 1},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{"palindrome phrase", "find all palindromes", true},
+		{"longer than phrase", "strings longer than 5", true},
+		{"single word phrase", "single word strings", true},
+		{"combined phrase", "single word palindromes longer than 5", true},
+		{"contains phrase", "strings that contain letter e", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := translateNaturalLanguageToExpr(tt.query)
+			if err != nil {
+				t.Fatalf("translateNaturalLanguageToExpr(%q): unexpected error: %v", tt.query, err)
+			}
+			if got := expr.Evaluate(row); got != tt.want {
+				t.Errorf("translateNaturalLanguageToExpr(%q).Evaluate(row) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateNaturalLanguageToExprNoMatch(t *testing.T) {
+	if _, err := translateNaturalLanguageToExpr("zzz qqq flibbertigibbet"); err == nil {
+		t.Error("translateNaturalLanguageToExpr(unrecognizable query) = nil error, want an error")
+	}
+}
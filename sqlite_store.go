@@ -0,0 +1,223 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore is a Store backed by an embedded SQLite database via
+// modernc.org/sqlite, which is pure Go and needs no cgo.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// sqliteBusyTimeoutMillis bounds how long a connection waits on SQLITE_BUSY
+// before giving up, so a write that loses a brief lock race retries instead
+// of failing outright.
+const sqliteBusyTimeoutMillis = 5000
+
+// NewSQLiteStore opens (and migrates) the SQLite database at path. SQLite
+// only ever allows one writer at a time, so the connection pool is capped
+// at a single connection — otherwise Gin's concurrent handlers open
+// multiple connections that immediately contend for the same file lock and
+// fail with SQLITE_BUSY instead of just queuing, even with a busy timeout set.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("%s?_pragma=busy_timeout(%d)", path, sqliteBusyTimeoutMillis))
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite store: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS analyzed_strings (
+			id         TEXT PRIMARY KEY,
+			value      TEXT NOT NULL,
+			properties JSONB NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		)
+	`); err != nil {
+		return nil, fmt.Errorf("creating analyzed_strings table: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Put(analyzed *AnalyzedString) error {
+	props, err := json.Marshal(analyzed.Properties)
+	if err != nil {
+		return fmt.Errorf("marshaling properties: %w", err)
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO analyzed_strings (id, value, properties, created_at) VALUES (?, ?, ?, ?)`,
+		analyzed.ID, analyzed.Value, props, analyzed.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueConstraintErr(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("inserting analyzed string: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Get(id string) (*AnalyzedString, bool, error) {
+	row := s.db.QueryRow(`SELECT id, value, properties, created_at FROM analyzed_strings WHERE id = ?`, id)
+
+	analyzed, err := scanAnalyzedString(row)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return analyzed, true, nil
+}
+
+func (s *SQLiteStore) Delete(id string) (bool, error) {
+	res, err := s.db.Exec(`DELETE FROM analyzed_strings WHERE id = ?`, id)
+	if err != nil {
+		return false, fmt.Errorf("deleting analyzed string: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("checking delete result: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (s *SQLiteStore) List(params FilterParams, page PageParams) ([]AnalyzedString, int, error) {
+	where, args := buildWhereClause(params)
+
+	var total int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM analyzed_strings"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting analyzed strings: %w", err)
+	}
+
+	query := "SELECT id, value, properties, created_at FROM analyzed_strings" + where +
+		sqlOrderBy(page.SortBy, page.SortOrder) + " LIMIT ? OFFSET ?"
+	rows, err := s.db.Query(query, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing analyzed strings: %w", err)
+	}
+	defer rows.Close()
+
+	results, err := scanAnalyzedStrings(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+func (s *SQLiteStore) Aggregate(params FilterParams) ([]AnalyzedString, error) {
+	where, args := buildWhereClause(params)
+
+	rows, err := s.db.Query("SELECT id, value, properties, created_at FROM analyzed_strings"+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating analyzed strings: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAnalyzedStrings(rows)
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAnalyzedString(row rowScanner) (*AnalyzedString, error) {
+	var a AnalyzedString
+	var propsJSON []byte
+
+	if err := row.Scan(&a.ID, &a.Value, &propsJSON, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(propsJSON, &a.Properties); err != nil {
+		return nil, fmt.Errorf("unmarshaling properties: %w", err)
+	}
+	return &a, nil
+}
+
+func scanAnalyzedStrings(rows *sql.Rows) ([]AnalyzedString, error) {
+	var results []AnalyzedString
+	for rows.Next() {
+		a, err := scanAnalyzedString(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *a)
+	}
+	return results, rows.Err()
+}
+
+// buildWhereClause pushes FilterParams down to a SQL WHERE clause operating
+// directly on the stored properties JSONB column.
+func buildWhereClause(params FilterParams) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if params.IsPalindrome != nil {
+		conditions = append(conditions, "json_extract(properties, '$.is_palindrome') = ?")
+		args = append(args, *params.IsPalindrome)
+	}
+	if params.MinLength != nil {
+		conditions = append(conditions, "json_extract(properties, '$.length') >= ?")
+		args = append(args, *params.MinLength)
+	}
+	if params.MaxLength != nil {
+		conditions = append(conditions, "json_extract(properties, '$.length') <= ?")
+		args = append(args, *params.MaxLength)
+	}
+	if params.WordCount != nil {
+		conditions = append(conditions, "json_extract(properties, '$.word_count') = ?")
+		args = append(args, *params.WordCount)
+	}
+	if params.ContainsCharacter != nil {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM json_each(json_extract(properties, '$.character_frequency_map'))
+			WHERE json_each.key = ?
+		)`)
+		args = append(args, strings.ToLower(*params.ContainsCharacter))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// sqlOrderBy builds an ORDER BY clause for the given sort field/order,
+// always breaking ties ascending by id. This mirrors sortResults' in-memory
+// tiebreak: a SQL engine's order among rows tied on the sort column isn't
+// guaranteed stable across query-plan changes (an ANALYZE, a new index, a
+// VACUUM), so without an explicit secondary key paginated results could
+// reorder between calls.
+func sqlOrderBy(sortBy, sortOrder string) string {
+	columns := map[string]string{
+		"created_at":        "created_at",
+		"length":            "json_extract(properties, '$.length')",
+		"word_count":        "json_extract(properties, '$.word_count')",
+		"unique_characters": "json_extract(properties, '$.unique_characters')",
+	}
+
+	column, ok := columns[sortBy]
+	if !ok {
+		column = "created_at"
+	}
+
+	direction := "ASC"
+	if sortOrder == "desc" {
+		direction = "DESC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", column, direction, direction)
+}
+
+func isUniqueConstraintErr(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
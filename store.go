@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrAlreadyExists is returned by Store.Put when a string with the same ID
+// is already present.
+var ErrAlreadyExists = errors.New("string already exists in the system")
+
+// PageParams describes the ordering and pagination window for Store.List.
+type PageParams struct {
+	Offset    int
+	Limit     int
+	SortBy    string
+	SortOrder string
+}
+
+// Store is the persistence interface for analyzed strings. Implementations
+// must be safe for concurrent use since Gin dispatches handlers on multiple
+// goroutines.
+type Store interface {
+	Put(analyzed *AnalyzedString) error
+	Get(id string) (*AnalyzedString, bool, error)
+	Delete(id string) (bool, error)
+	List(params FilterParams, page PageParams) ([]AnalyzedString, int, error)
+	Aggregate(params FilterParams) ([]AnalyzedString, error)
+}
+
+// MemoryStore is the original map-backed Store, now guarded by a RWMutex so
+// it is safe under Gin's concurrent handlers (the previous bare map had a
+// data race between reads and writes).
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data map[string]*AnalyzedString
+}
+
+// NewMemoryStore creates an empty in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: make(map[string]*AnalyzedString)}
+}
+
+func (s *MemoryStore) Put(analyzed *AnalyzedString) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.data[analyzed.ID]; exists {
+		return ErrAlreadyExists
+	}
+	s.data[analyzed.ID] = analyzed
+	return nil
+}
+
+func (s *MemoryStore) Get(id string) (*AnalyzedString, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	analyzed, exists := s.data[id]
+	if !exists {
+		return nil, false, nil
+	}
+	copied := *analyzed
+	return &copied, true, nil
+}
+
+func (s *MemoryStore) Delete(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.data[id]; !exists {
+		return false, nil
+	}
+	delete(s.data, id)
+	return true, nil
+}
+
+func (s *MemoryStore) List(params FilterParams, page PageParams) ([]AnalyzedString, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []AnalyzedString
+	for _, analyzed := range s.data {
+		if matchesFilters(analyzed, params) {
+			results = append(results, *analyzed)
+		}
+	}
+
+	sortResults(results, page.SortBy, page.SortOrder)
+	total := len(results)
+	return paginate(results, page.Offset, page.Limit), total, nil
+}
+
+func (s *MemoryStore) Aggregate(params FilterParams) ([]AnalyzedString, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []AnalyzedString
+	for _, analyzed := range s.data {
+		if matchesFilters(analyzed, params) {
+			results = append(results, *analyzed)
+		}
+	}
+	return results, nil
+}
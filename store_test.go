@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryStorePutRejectsDuplicates(t *testing.T) {
+	store := NewMemoryStore()
+	analyzed := &AnalyzedString{ID: "id-1", Value: "a", CreatedAt: time.Now().UTC()}
+
+	if err := store.Put(analyzed); err != nil {
+		t.Fatalf("first Put: unexpected error %v", err)
+	}
+	if err := store.Put(analyzed); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("second Put: got %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestMemoryStoreConcurrentPutIsRaceFree(t *testing.T) {
+	store := NewMemoryStore()
+
+	const writers = 50
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = store.Put(&AnalyzedString{ID: fmt.Sprintf("id-%d", i), CreatedAt: time.Now().UTC()})
+		}(i)
+	}
+	wg.Wait()
+
+	results, total, err := store.List(FilterParams{}, PageParams{Limit: 1000, SortBy: "created_at", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("List: unexpected error %v", err)
+	}
+	if total != writers || len(results) != writers {
+		t.Fatalf("total = %d, len(results) = %d, want %d", total, len(results), writers)
+	}
+}
+
+func TestSQLiteStoreConcurrentPutSucceeds(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := NewSQLiteStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+
+	const writers = 50
+	errs := make([]error, writers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("id-%d", i)
+			errs[i] = store.Put(&AnalyzedString{
+				ID:         id,
+				Value:      id,
+				Properties: StringProperties{CharacterFrequencyMap: map[string]int{}},
+				CreatedAt:  time.Now().UTC(),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("concurrent Put %d: unexpected error %v (SQLITE_BUSY under load indicates the connection pool / busy_timeout regressed)", i, err)
+		}
+	}
+}
+
+func TestBuildWhereClause(t *testing.T) {
+	isPalindrome := true
+	minLength := 3
+	char := "E"
+
+	where, args := buildWhereClause(FilterParams{
+		IsPalindrome:      &isPalindrome,
+		MinLength:         &minLength,
+		ContainsCharacter: &char,
+	})
+
+	if where == "" {
+		t.Fatal("expected a non-empty WHERE clause")
+	}
+	if len(args) != 3 {
+		t.Fatalf("len(args) = %d, want 3", len(args))
+	}
+	if args[2] != "e" {
+		t.Errorf("ContainsCharacter arg = %v, want lowercased 'e'", args[2])
+	}
+}
+
+func TestBuildWhereClauseNoFilters(t *testing.T) {
+	where, args := buildWhereClause(FilterParams{})
+	if where != "" {
+		t.Errorf("where = %q, want empty", where)
+	}
+	if len(args) != 0 {
+		t.Errorf("len(args) = %d, want 0", len(args))
+	}
+}
+
+func TestSQLOrderBy(t *testing.T) {
+	tests := []struct {
+		sortBy, sortOrder, want string
+	}{
+		{"length", "desc", " ORDER BY json_extract(properties, '$.length') DESC, id DESC"},
+		{"created_at", "asc", " ORDER BY created_at ASC, id ASC"},
+		{"unknown_field", "asc", " ORDER BY created_at ASC, id ASC"},
+	}
+
+	for _, tt := range tests {
+		if got := sqlOrderBy(tt.sortBy, tt.sortOrder); got != tt.want {
+			t.Errorf("sqlOrderBy(%q, %q) = %q, want %q", tt.sortBy, tt.sortOrder, got, tt.want)
+		}
+	}
+}